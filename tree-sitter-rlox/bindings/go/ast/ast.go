@@ -0,0 +1,265 @@
+// Package ast converts the untyped tree-sitter CST produced by
+// bindings/go into a typed Go AST mirroring Crafting Interpreters' Lox
+// grammar, so downstream tools (interpreters, linters, formatters) can
+// pattern-match on node kinds instead of comparing tree-sitter node
+// type strings.
+package ast
+
+import (
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// Range identifies the span of source a node came from.
+type Range struct {
+	StartByte, EndByte   uint
+	StartPoint, EndPoint tree_sitter.Point
+}
+
+// Node is implemented by every AST node.
+type Node interface {
+	Range() Range
+}
+
+// Stmt is implemented by statement and declaration nodes.
+type Stmt interface {
+	Node
+	stmtNode()
+}
+
+// Expr is implemented by expression nodes.
+type Expr interface {
+	Node
+	exprNode()
+}
+
+type base struct {
+	Rng Range
+}
+
+// Range returns the source span the node was parsed from.
+func (b base) Range() Range { return b.Rng }
+
+// Program is the root of a parsed Lox file: a sequence of top-level
+// declarations.
+type Program struct {
+	base
+	Decls []Stmt
+}
+
+// VarDecl is a `var name = init;` or `var name;` declaration.
+type VarDecl struct {
+	base
+	Name string
+	Init Expr // nil if the declaration has no initializer
+}
+
+func (*VarDecl) stmtNode() {}
+
+// FunDecl is a `fun name(params) { body }` declaration, also used for
+// methods inside a ClassDecl (where Name is the method name).
+type FunDecl struct {
+	base
+	Name   string
+	Params []string
+	Body   []Stmt
+}
+
+func (*FunDecl) stmtNode() {}
+
+// ClassDecl is a `class Name < Superclass { methods... }` declaration.
+type ClassDecl struct {
+	base
+	Name       string
+	Superclass *Variable // nil if there is no superclass
+	Methods    []*FunDecl
+}
+
+func (*ClassDecl) stmtNode() {}
+
+// Block is a `{ ... }` statement sequence, and introduces its own
+// lexical scope.
+type Block struct {
+	base
+	Stmts []Stmt
+}
+
+func (*Block) stmtNode() {}
+
+// IfStmt is an `if (Cond) Then else Else` statement. Else is nil when
+// there is no else branch.
+type IfStmt struct {
+	base
+	Cond Expr
+	Then Stmt
+	Else Stmt
+}
+
+func (*IfStmt) stmtNode() {}
+
+// WhileStmt is a `while (Cond) Body` statement.
+type WhileStmt struct {
+	base
+	Cond Expr
+	Body Stmt
+}
+
+func (*WhileStmt) stmtNode() {}
+
+// ForStmt is a `for (Init; Cond; Post) Body` statement, kept as its own
+// node rather than desugared into a WhileStmt. Init,
+// Cond and Post are each nil when the corresponding clause is omitted.
+type ForStmt struct {
+	base
+	Init Stmt
+	Cond Expr
+	Post Expr
+	Body Stmt
+}
+
+func (*ForStmt) stmtNode() {}
+
+// ReturnStmt is a `return Value;` or bare `return;` statement. Value is
+// nil in the bare form.
+type ReturnStmt struct {
+	base
+	Value Expr
+}
+
+func (*ReturnStmt) stmtNode() {}
+
+// PrintStmt is a `print Value;` statement.
+type PrintStmt struct {
+	base
+	Value Expr
+}
+
+func (*PrintStmt) stmtNode() {}
+
+// ExprStmt is a bare expression used as a statement, e.g. a call for
+// its side effects.
+type ExprStmt struct {
+	base
+	Expr Expr
+}
+
+func (*ExprStmt) stmtNode() {}
+
+// Binary is a binary arithmetic/comparison/equality expression.
+type Binary struct {
+	base
+	Left  Expr
+	Op    string
+	Right Expr
+}
+
+func (*Binary) exprNode() {}
+
+// Logical is `and`/`or`, kept distinct from Binary because both
+// operators short-circuit.
+type Logical struct {
+	base
+	Left  Expr
+	Op    string
+	Right Expr
+}
+
+func (*Logical) exprNode() {}
+
+// Unary is a prefix `-` or `!` expression.
+type Unary struct {
+	base
+	Op    string
+	Right Expr
+}
+
+func (*Unary) exprNode() {}
+
+// Grouping is a parenthesized expression, `(Expr)`. It's kept as its
+// own node rather than being collapsed into Expr so that precedence
+// the source author wrote explicitly survives a round trip through
+// cmd/rlox-fmt instead of being silently re-derived from operator
+// precedence.
+type Grouping struct {
+	base
+	Expr Expr
+}
+
+func (*Grouping) exprNode() {}
+
+// Call is a function or method call expression.
+type Call struct {
+	base
+	Callee Expr
+	Args   []Expr
+}
+
+func (*Call) exprNode() {}
+
+// Get is a property read, `Object.Name`.
+type Get struct {
+	base
+	Object Expr
+	Name   string
+}
+
+func (*Get) exprNode() {}
+
+// Set is a property write, `Object.Name = Value`.
+type Set struct {
+	base
+	Object Expr
+	Name   string
+	Value  Expr
+}
+
+func (*Set) exprNode() {}
+
+// Super is a `super.Method` expression.
+type Super struct {
+	base
+	Method string
+}
+
+func (*Super) exprNode() {}
+
+// This is the `this` expression.
+type This struct {
+	base
+}
+
+func (*This) exprNode() {}
+
+// Literal is a number, string, boolean, or nil literal. Value holds the
+// node's raw source text exactly as written (e.g. `"1.50"` stays
+// "1.50", a string keeps its surrounding quotes) rather than a parsed
+// Go value, since consumers like cmd/rlox-fmt need the original
+// spelling, not a normalized one.
+//
+// An interpolated string (`"...${expr}..."`) is also collapsed into a
+// single Literal holding its entire raw text, including the `${...}`
+// parts. Walk and Resolver therefore never see the expressions inside
+// an interpolation, so a variable used only within one is silently
+// skipped by resolution.
+type Literal struct {
+	base
+	Value string
+}
+
+func (*Literal) exprNode() {}
+
+// Variable is a bare identifier used as an expression.
+type Variable struct {
+	base
+	Name string
+}
+
+func (*Variable) exprNode() {}
+
+// Assign is a `Name = Value` assignment expression.
+type Assign struct {
+	base
+	Name  string
+	Value Expr
+}
+
+func (*Assign) exprNode() {}
@@ -0,0 +1,97 @@
+package ast_test
+
+import (
+	"testing"
+
+	tree_sitter_rlox "github.com/abrishk26/rlox/bindings/go"
+	"github.com/abrishk26/rlox/bindings/go/ast"
+)
+
+func parseProgram(t *testing.T, src string) *ast.Program {
+	t.Helper()
+	p, err := tree_sitter_rlox.NewParser()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree := p.Parse([]byte(src))
+	program, err := ast.FromTree(tree, []byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return program
+}
+
+func TestFromTreeClosure(t *testing.T) {
+	program := parseProgram(t, `
+fun makeCounter() {
+  var count = 0;
+  fun increment() {
+    count = count + 1;
+    return count;
+  }
+  return increment;
+}
+`)
+
+	if len(program.Decls) != 1 {
+		t.Fatalf("expected 1 top-level declaration, got %d", len(program.Decls))
+	}
+	fn, ok := program.Decls[0].(*ast.FunDecl)
+	if !ok {
+		t.Fatalf("expected *ast.FunDecl, got %T", program.Decls[0])
+	}
+	if fn.Name != "makeCounter" {
+		t.Errorf("expected function named makeCounter, got %q", fn.Name)
+	}
+}
+
+func TestResolverClosureBinding(t *testing.T) {
+	program := parseProgram(t, `
+fun makeCounter() {
+  var count = 0;
+  fun increment() {
+    count = count + 1;
+    return count;
+  }
+  return increment;
+}
+`)
+
+	r := ast.NewResolver()
+	_, errs := r.Resolve(program)
+	if len(errs) != 0 {
+		t.Fatalf("unexpected resolve errors: %v", errs)
+	}
+}
+
+func TestResolverRejectsThisOutsideClass(t *testing.T) {
+	program := parseProgram(t, `print this;`)
+
+	r := ast.NewResolver()
+	_, errs := r.Resolve(program)
+	if len(errs) != 1 {
+		t.Fatalf("expected exactly one resolve error, got %d: %v", len(errs), errs)
+	}
+}
+
+func TestResolverBindsThisAndSuperInMethod(t *testing.T) {
+	program := parseProgram(t, `
+class Base {
+  greet() {
+    return "hi";
+  }
+}
+
+class Derived < Base {
+  greet() {
+    return super.greet() + " from " + this.name;
+  }
+}
+`)
+
+	r := ast.NewResolver()
+	_, errs := r.Resolve(program)
+	if len(errs) != 0 {
+		t.Fatalf("unexpected resolve errors: %v", errs)
+	}
+}
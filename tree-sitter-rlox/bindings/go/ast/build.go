@@ -0,0 +1,370 @@
+package ast
+
+import (
+	"fmt"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+
+	tree_sitter_rlox "github.com/abrishk26/rlox/bindings/go"
+)
+
+// FromTree converts a parsed tree into a typed *Program. Field names
+// looked up on CST nodes (name, callee, property, condition, ...)
+// are the field(...) names declared in grammar.js.
+func FromTree(tree *tree_sitter_rlox.Tree, src []byte) (*Program, error) {
+	b := &builder{src: src}
+	root := tree.RootNode()
+	decls, err := b.stmts(root)
+	if err != nil {
+		return nil, err
+	}
+	return &Program{base: base{Rng: rangeOf(root)}, Decls: decls}, nil
+}
+
+type builder struct {
+	src []byte
+}
+
+func rangeOf(n tree_sitter.Node) Range {
+	return Range{
+		StartByte:  n.StartByte(),
+		EndByte:    n.EndByte(),
+		StartPoint: n.StartPosition(),
+		EndPoint:   n.EndPosition(),
+	}
+}
+
+func (b *builder) text(n tree_sitter.Node) string {
+	s, _ := n.Utf8Text(b.src)
+	return s
+}
+
+// stmts converts every named child of n into a Stmt.
+func (b *builder) stmts(n tree_sitter.Node) ([]Stmt, error) {
+	var out []Stmt
+	for i := 0; i < int(n.NamedChildCount()); i++ {
+		s, err := b.stmt(n.NamedChild(i))
+		if err != nil {
+			return nil, err
+		}
+		if s != nil {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+func (b *builder) stmt(n tree_sitter.Node) (Stmt, error) {
+	switch n.Type() {
+	case "var_declaration":
+		var init Expr
+		if v := n.ChildByFieldName("value"); !v.IsNull() {
+			e, err := b.expr(v)
+			if err != nil {
+				return nil, err
+			}
+			init = e
+		}
+		return &VarDecl{base: base{Rng: rangeOf(n)}, Name: b.text(n.ChildByFieldName("name")), Init: init}, nil
+
+	case "fun_declaration":
+		return b.funDecl(n)
+
+	case "class_declaration":
+		return b.classDecl(n)
+
+	case "block":
+		stmts, err := b.stmts(n)
+		if err != nil {
+			return nil, err
+		}
+		return &Block{base: base{Rng: rangeOf(n)}, Stmts: stmts}, nil
+
+	case "if_statement":
+		cond, err := b.expr(n.ChildByFieldName("condition"))
+		if err != nil {
+			return nil, err
+		}
+		then, err := b.stmt(n.ChildByFieldName("consequence"))
+		if err != nil {
+			return nil, err
+		}
+		var els Stmt
+		if alt := n.ChildByFieldName("alternative"); !alt.IsNull() {
+			els, err = b.stmt(alt)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return &IfStmt{base: base{Rng: rangeOf(n)}, Cond: cond, Then: then, Else: els}, nil
+
+	case "while_statement":
+		cond, err := b.expr(n.ChildByFieldName("condition"))
+		if err != nil {
+			return nil, err
+		}
+		body, err := b.stmt(n.ChildByFieldName("body"))
+		if err != nil {
+			return nil, err
+		}
+		return &WhileStmt{base: base{Rng: rangeOf(n)}, Cond: cond, Body: body}, nil
+
+	case "for_statement":
+		return b.forStmt(n)
+
+	case "return_statement":
+		var value Expr
+		if v := n.ChildByFieldName("value"); !v.IsNull() {
+			e, err := b.expr(v)
+			if err != nil {
+				return nil, err
+			}
+			value = e
+		}
+		return &ReturnStmt{base: base{Rng: rangeOf(n)}, Value: value}, nil
+
+	case "print_statement":
+		value, err := b.expr(n.ChildByFieldName("value"))
+		if err != nil {
+			return nil, err
+		}
+		return &PrintStmt{base: base{Rng: rangeOf(n)}, Value: value}, nil
+
+	case "expression_statement":
+		e, err := b.expr(n.NamedChild(0))
+		if err != nil {
+			return nil, err
+		}
+		return &ExprStmt{base: base{Rng: rangeOf(n)}, Expr: e}, nil
+
+	case "comment":
+		return nil, nil
+
+	default:
+		return nil, fmt.Errorf("ast: unrecognized statement node %q", n.Type())
+	}
+}
+
+func (b *builder) funDecl(n tree_sitter.Node) (*FunDecl, error) {
+	var params []string
+	if p := n.ChildByFieldName("parameters"); !p.IsNull() {
+		for i := 0; i < int(p.NamedChildCount()); i++ {
+			params = append(params, b.text(p.NamedChild(i)))
+		}
+	}
+	body, err := b.stmts(n.ChildByFieldName("body"))
+	if err != nil {
+		return nil, err
+	}
+	return &FunDecl{
+		base:   base{Rng: rangeOf(n)},
+		Name:   b.text(n.ChildByFieldName("name")),
+		Params: params,
+		Body:   body,
+	}, nil
+}
+
+func (b *builder) classDecl(n tree_sitter.Node) (*ClassDecl, error) {
+	var super *Variable
+	if s := n.ChildByFieldName("superclass"); !s.IsNull() {
+		super = &Variable{base: base{Rng: rangeOf(s)}, Name: b.text(s)}
+	}
+
+	methods, err := b.classMethods(n)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ClassDecl{
+		base:       base{Rng: rangeOf(n)},
+		Name:       b.text(n.ChildByFieldName("name")),
+		Superclass: super,
+		Methods:    methods,
+	}, nil
+}
+
+// classMethods reconstructs the class's methods from the flattened
+// name/parameters/body fields that grammar.js's repeat($._function)
+// splices directly into class_declaration's children (methods, unlike
+// top-level functions, aren't wrapped in their own fun_declaration
+// node). The class's own name is always the first "name" field seen;
+// every "name" field after that starts a new method.
+func (b *builder) classMethods(n tree_sitter.Node) ([]*FunDecl, error) {
+	var methods []*FunDecl
+	var cur *FunDecl
+	sawClassName := false
+
+	for i := 0; i < int(n.ChildCount()); i++ {
+		child := n.Child(i)
+		switch n.FieldNameForChild(i) {
+		case "name":
+			if !sawClassName {
+				sawClassName = true
+				continue
+			}
+			cur = &FunDecl{base: base{Rng: rangeOf(child)}, Name: b.text(child)}
+			methods = append(methods, cur)
+
+		case "parameters":
+			if cur == nil {
+				continue
+			}
+			for j := 0; j < int(child.NamedChildCount()); j++ {
+				cur.Params = append(cur.Params, b.text(child.NamedChild(j)))
+			}
+
+		case "body":
+			if cur == nil {
+				continue
+			}
+			stmts, err := b.stmts(child)
+			if err != nil {
+				return nil, err
+			}
+			cur.Body = stmts
+			cur.Rng.EndByte = child.EndByte()
+			cur.Rng.EndPoint = child.EndPosition()
+		}
+	}
+
+	return methods, nil
+}
+
+func (b *builder) forStmt(n tree_sitter.Node) (*ForStmt, error) {
+	var init Stmt
+	var err error
+	// The initializer field may be a bare ';' (grammar.js allows
+	// `for (;;)`), which carries no statement to build.
+	if i := n.ChildByFieldName("initializer"); !i.IsNull() && i.Type() != ";" {
+		init, err = b.stmt(i)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var cond Expr
+	if c := n.ChildByFieldName("condition"); !c.IsNull() {
+		cond, err = b.expr(c)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var post Expr
+	if p := n.ChildByFieldName("increment"); !p.IsNull() {
+		post, err = b.expr(p)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	body, err := b.stmt(n.ChildByFieldName("body"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ForStmt{base: base{Rng: rangeOf(n)}, Init: init, Cond: cond, Post: post, Body: body}, nil
+}
+
+func (b *builder) expr(n tree_sitter.Node) (Expr, error) {
+	switch n.Type() {
+	case "binary_expression":
+		left, err := b.expr(n.ChildByFieldName("left"))
+		if err != nil {
+			return nil, err
+		}
+		right, err := b.expr(n.ChildByFieldName("right"))
+		if err != nil {
+			return nil, err
+		}
+		return &Binary{base: base{Rng: rangeOf(n)}, Left: left, Op: b.text(n.ChildByFieldName("operator")), Right: right}, nil
+
+	case "logical_expression":
+		left, err := b.expr(n.ChildByFieldName("left"))
+		if err != nil {
+			return nil, err
+		}
+		right, err := b.expr(n.ChildByFieldName("right"))
+		if err != nil {
+			return nil, err
+		}
+		return &Logical{base: base{Rng: rangeOf(n)}, Left: left, Op: b.text(n.ChildByFieldName("operator")), Right: right}, nil
+
+	case "unary_expression":
+		right, err := b.expr(n.ChildByFieldName("operand"))
+		if err != nil {
+			return nil, err
+		}
+		return &Unary{base: base{Rng: rangeOf(n)}, Op: b.text(n.ChildByFieldName("operator")), Right: right}, nil
+
+	case "call_expression":
+		callee, err := b.expr(n.ChildByFieldName("callee"))
+		if err != nil {
+			return nil, err
+		}
+		var args []Expr
+		if a := n.ChildByFieldName("arguments"); !a.IsNull() {
+			for i := 0; i < int(a.NamedChildCount()); i++ {
+				arg, err := b.expr(a.NamedChild(i))
+				if err != nil {
+					return nil, err
+				}
+				args = append(args, arg)
+			}
+		}
+		return &Call{base: base{Rng: rangeOf(n)}, Callee: callee, Args: args}, nil
+
+	case "get_expression":
+		object, err := b.expr(n.ChildByFieldName("object"))
+		if err != nil {
+			return nil, err
+		}
+		return &Get{base: base{Rng: rangeOf(n)}, Object: object, Name: b.text(n.ChildByFieldName("property"))}, nil
+
+	case "set_expression":
+		object, err := b.expr(n.ChildByFieldName("object"))
+		if err != nil {
+			return nil, err
+		}
+		value, err := b.expr(n.ChildByFieldName("value"))
+		if err != nil {
+			return nil, err
+		}
+		return &Set{base: base{Rng: rangeOf(n)}, Object: object, Name: b.text(n.ChildByFieldName("property")), Value: value}, nil
+
+	case "super_expression":
+		return &Super{base: base{Rng: rangeOf(n)}, Method: b.text(n.ChildByFieldName("method"))}, nil
+
+	case "this_expression":
+		return &This{base: base{Rng: rangeOf(n)}}, nil
+
+	case "assignment_expression":
+		value, err := b.expr(n.ChildByFieldName("value"))
+		if err != nil {
+			return nil, err
+		}
+		return &Assign{base: base{Rng: rangeOf(n)}, Name: b.text(n.ChildByFieldName("name")), Value: value}, nil
+
+	case "identifier":
+		return &Variable{base: base{Rng: rangeOf(n)}, Name: b.text(n)}, nil
+
+	case "number":
+		return &Literal{base: base{Rng: rangeOf(n)}, Value: b.text(n)}, nil
+
+	case "string":
+		return &Literal{base: base{Rng: rangeOf(n)}, Value: b.text(n)}, nil
+
+	case "true", "false", "nil":
+		return &Literal{base: base{Rng: rangeOf(n)}, Value: b.text(n)}, nil
+
+	case "grouping_expression":
+		inner, err := b.expr(n.NamedChild(0))
+		if err != nil {
+			return nil, err
+		}
+		return &Grouping{base: base{Rng: rangeOf(n)}, Expr: inner}, nil
+
+	default:
+		return nil, fmt.Errorf("ast: unrecognized expression node %q", n.Type())
+	}
+}
@@ -0,0 +1,277 @@
+package ast
+
+import "fmt"
+
+// FunctionType tracks what kind of function body the resolver is
+// currently inside, so a `return` with a value inside an initializer
+// can be reported. `this` is validated from ClassType instead.
+type FunctionType int
+
+const (
+	FunctionNone FunctionType = iota
+	FunctionFunction
+	FunctionMethod
+	FunctionInitializer
+)
+
+// ClassType tracks whether the resolver is inside a class body, and
+// whether that class has a superclass, so `this` and `super` can be
+// validated.
+type ClassType int
+
+const (
+	ClassNone ClassType = iota
+	ClassClass
+	ClassSubclass
+)
+
+// ResolveError is a resolution-time problem, e.g. `this` used outside
+// a method, or a variable read in its own initializer.
+type ResolveError struct {
+	Range   Range
+	Message string
+}
+
+func (e *ResolveError) Error() string { return e.Message }
+
+// Resolver performs the Crafting Interpreters-style variable
+// resolution pass over an ast.Program: for every Variable, Assign,
+// This and Super expression it computes how many enclosing scopes
+// separate the use from its declaration, so an interpreter can look
+// the binding up directly instead of walking an environment chain at
+// runtime.
+type Resolver struct {
+	scopes []map[string]bool
+	locals map[Expr]int
+	fn     FunctionType
+	cls    ClassType
+	errs   []*ResolveError
+}
+
+// NewResolver returns a Resolver ready to resolve a Program.
+func NewResolver() *Resolver {
+	return &Resolver{locals: map[Expr]int{}}
+}
+
+// Resolve walks program and returns, for every expression that reads or
+// assigns a local variable, the number of scopes between the use and
+// its declaration (0 means the innermost scope). Expressions resolving
+// to a global are absent from the map. Any problems found along the
+// way are returned as errs rather than stopping the walk.
+func (r *Resolver) Resolve(program *Program) (locals map[Expr]int, errs []*ResolveError) {
+	r.resolveStmts(program.Decls)
+	return r.locals, r.errs
+}
+
+func (r *Resolver) error(rng Range, format string, args ...interface{}) {
+	r.errs = append(r.errs, &ResolveError{Range: rng, Message: fmt.Sprintf(format, args...)})
+}
+
+func (r *Resolver) beginScope() { r.scopes = append(r.scopes, map[string]bool{}) }
+func (r *Resolver) endScope()   { r.scopes = r.scopes[:len(r.scopes)-1] }
+
+func (r *Resolver) declare(name string) {
+	if len(r.scopes) == 0 {
+		return
+	}
+	r.scopes[len(r.scopes)-1][name] = false
+}
+
+func (r *Resolver) define(name string) {
+	if len(r.scopes) == 0 {
+		return
+	}
+	r.scopes[len(r.scopes)-1][name] = true
+}
+
+func (r *Resolver) resolveLocal(expr Expr, name string) {
+	for i := len(r.scopes) - 1; i >= 0; i-- {
+		if _, ok := r.scopes[i][name]; ok {
+			r.locals[expr] = len(r.scopes) - 1 - i
+			return
+		}
+	}
+	// Not found in any scope: treated as a global, resolved at runtime.
+}
+
+func (r *Resolver) resolveStmts(stmts []Stmt) {
+	for _, s := range stmts {
+		r.resolveStmt(s)
+	}
+}
+
+func (r *Resolver) resolveStmt(s Stmt) {
+	switch s := s.(type) {
+	case *VarDecl:
+		r.declare(s.Name)
+		if s.Init != nil {
+			r.resolveExpr(s.Init)
+		}
+		r.define(s.Name)
+
+	case *FunDecl:
+		r.declare(s.Name)
+		r.define(s.Name)
+		r.resolveFunction(s, FunctionFunction)
+
+	case *ClassDecl:
+		r.resolveClass(s)
+
+	case *Block:
+		r.beginScope()
+		r.resolveStmts(s.Stmts)
+		r.endScope()
+
+	case *IfStmt:
+		r.resolveExpr(s.Cond)
+		r.resolveStmt(s.Then)
+		if s.Else != nil {
+			r.resolveStmt(s.Else)
+		}
+
+	case *WhileStmt:
+		r.resolveExpr(s.Cond)
+		r.resolveStmt(s.Body)
+
+	case *ForStmt:
+		r.beginScope()
+		if s.Init != nil {
+			r.resolveStmt(s.Init)
+		}
+		if s.Cond != nil {
+			r.resolveExpr(s.Cond)
+		}
+		if s.Post != nil {
+			r.resolveExpr(s.Post)
+		}
+		r.resolveStmt(s.Body)
+		r.endScope()
+
+	case *ReturnStmt:
+		if s.Value != nil {
+			if r.fn == FunctionInitializer {
+				r.error(s.Range(), "can't return a value from an initializer")
+			}
+			r.resolveExpr(s.Value)
+		}
+
+	case *PrintStmt:
+		r.resolveExpr(s.Value)
+
+	case *ExprStmt:
+		r.resolveExpr(s.Expr)
+	}
+}
+
+func (r *Resolver) resolveFunction(fn *FunDecl, kind FunctionType) {
+	enclosing := r.fn
+	r.fn = kind
+	defer func() { r.fn = enclosing }()
+
+	r.beginScope()
+	for _, p := range fn.Params {
+		r.declare(p)
+		r.define(p)
+	}
+	r.resolveStmts(fn.Body)
+	r.endScope()
+}
+
+func (r *Resolver) resolveClass(c *ClassDecl) {
+	enclosingCls := r.cls
+	r.cls = ClassClass
+	defer func() { r.cls = enclosingCls }()
+
+	r.declare(c.Name)
+	r.define(c.Name)
+
+	if c.Superclass != nil {
+		if c.Superclass.Name == c.Name {
+			r.error(c.Superclass.Range(), "a class can't inherit from itself")
+		}
+		r.cls = ClassSubclass
+		r.resolveExpr(c.Superclass)
+
+		r.beginScope()
+		r.scopes[len(r.scopes)-1]["super"] = true
+	}
+
+	r.beginScope()
+	r.scopes[len(r.scopes)-1]["this"] = true
+
+	for _, m := range c.Methods {
+		kind := FunctionMethod
+		if m.Name == "init" {
+			kind = FunctionInitializer
+		}
+		r.resolveFunction(m, kind)
+	}
+
+	r.endScope()
+
+	if c.Superclass != nil {
+		r.endScope()
+	}
+}
+
+func (r *Resolver) resolveExpr(e Expr) {
+	switch e := e.(type) {
+	case *Variable:
+		if len(r.scopes) > 0 {
+			if defined, ok := r.scopes[len(r.scopes)-1][e.Name]; ok && !defined {
+				r.error(e.Range(), "can't read local variable %q in its own initializer", e.Name)
+			}
+		}
+		r.resolveLocal(e, e.Name)
+
+	case *Assign:
+		r.resolveExpr(e.Value)
+		r.resolveLocal(e, e.Name)
+
+	case *Binary:
+		r.resolveExpr(e.Left)
+		r.resolveExpr(e.Right)
+
+	case *Logical:
+		r.resolveExpr(e.Left)
+		r.resolveExpr(e.Right)
+
+	case *Unary:
+		r.resolveExpr(e.Right)
+
+	case *Grouping:
+		r.resolveExpr(e.Expr)
+
+	case *Call:
+		r.resolveExpr(e.Callee)
+		for _, a := range e.Args {
+			r.resolveExpr(a)
+		}
+
+	case *Get:
+		r.resolveExpr(e.Object)
+
+	case *Set:
+		r.resolveExpr(e.Value)
+		r.resolveExpr(e.Object)
+
+	case *This:
+		if r.cls == ClassNone {
+			r.error(e.Range(), "can't use 'this' outside of a class")
+			return
+		}
+		r.resolveLocal(e, "this")
+
+	case *Super:
+		switch r.cls {
+		case ClassNone:
+			r.error(e.Range(), "can't use 'super' outside of a class")
+		case ClassClass:
+			r.error(e.Range(), "can't use 'super' in a class with no superclass")
+		}
+		r.resolveLocal(e, "super")
+
+	case *Literal:
+		// no identifiers to resolve
+	}
+}
@@ -0,0 +1,136 @@
+package ast
+
+// Visitor receives every statement and expression node Walk visits.
+// Implementations that only care about one kind can leave the other
+// method a no-op.
+type Visitor interface {
+	VisitStmt(Stmt)
+	VisitExpr(Expr)
+}
+
+// Walk traverses n depth-first, visiting a node before its children.
+func Walk(v Visitor, n Node) {
+	switch n := n.(type) {
+	case *Program:
+		for _, d := range n.Decls {
+			Walk(v, d)
+		}
+
+	case *VarDecl:
+		v.VisitStmt(n)
+		if n.Init != nil {
+			Walk(v, n.Init)
+		}
+
+	case *FunDecl:
+		v.VisitStmt(n)
+		for _, s := range n.Body {
+			Walk(v, s)
+		}
+
+	case *ClassDecl:
+		v.VisitStmt(n)
+		if n.Superclass != nil {
+			Walk(v, n.Superclass)
+		}
+		for _, m := range n.Methods {
+			Walk(v, m)
+		}
+
+	case *Block:
+		v.VisitStmt(n)
+		for _, s := range n.Stmts {
+			Walk(v, s)
+		}
+
+	case *IfStmt:
+		v.VisitStmt(n)
+		Walk(v, n.Cond)
+		Walk(v, n.Then)
+		if n.Else != nil {
+			Walk(v, n.Else)
+		}
+
+	case *WhileStmt:
+		v.VisitStmt(n)
+		Walk(v, n.Cond)
+		Walk(v, n.Body)
+
+	case *ForStmt:
+		v.VisitStmt(n)
+		if n.Init != nil {
+			Walk(v, n.Init)
+		}
+		if n.Cond != nil {
+			Walk(v, n.Cond)
+		}
+		if n.Post != nil {
+			Walk(v, n.Post)
+		}
+		Walk(v, n.Body)
+
+	case *ReturnStmt:
+		v.VisitStmt(n)
+		if n.Value != nil {
+			Walk(v, n.Value)
+		}
+
+	case *PrintStmt:
+		v.VisitStmt(n)
+		Walk(v, n.Value)
+
+	case *ExprStmt:
+		v.VisitStmt(n)
+		Walk(v, n.Expr)
+
+	case *Binary:
+		v.VisitExpr(n)
+		Walk(v, n.Left)
+		Walk(v, n.Right)
+
+	case *Logical:
+		v.VisitExpr(n)
+		Walk(v, n.Left)
+		Walk(v, n.Right)
+
+	case *Unary:
+		v.VisitExpr(n)
+		Walk(v, n.Right)
+
+	case *Grouping:
+		v.VisitExpr(n)
+		Walk(v, n.Expr)
+
+	case *Call:
+		v.VisitExpr(n)
+		Walk(v, n.Callee)
+		for _, a := range n.Args {
+			Walk(v, a)
+		}
+
+	case *Get:
+		v.VisitExpr(n)
+		Walk(v, n.Object)
+
+	case *Set:
+		v.VisitExpr(n)
+		Walk(v, n.Object)
+		Walk(v, n.Value)
+
+	case *Super:
+		v.VisitExpr(n)
+
+	case *This:
+		v.VisitExpr(n)
+
+	case *Literal:
+		v.VisitExpr(n)
+
+	case *Variable:
+		v.VisitExpr(n)
+
+	case *Assign:
+		v.VisitExpr(n)
+		Walk(v, n.Value)
+	}
+}
@@ -0,0 +1,14 @@
+package tree_sitter_rlox
+
+// #cgo CFLAGS: -std=c11 -fPIC
+// #include "../../src/parser.c"
+// #include "../../src/scanner.c"
+import "C"
+
+import "unsafe"
+
+// Language returns the tree-sitter Language for this grammar, suitable
+// for passing to tree_sitter.NewLanguage.
+func Language() unsafe.Pointer {
+	return unsafe.Pointer(C.tree_sitter_rlox())
+}
@@ -0,0 +1,156 @@
+// Package diagnostic turns the ERROR and MISSING nodes tree-sitter
+// leaves behind during error recovery into a flat list of human- and
+// editor-readable problems, instead of making callers walk the CST
+// themselves.
+package diagnostic
+
+import (
+	"fmt"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+
+	tree_sitter_rlox "github.com/abrishk26/rlox/bindings/go"
+)
+
+// Severity classifies how serious a Diagnostic is.
+type Severity int
+
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "unknown"
+	}
+}
+
+// Diagnostic codes, stable identifiers an editor can use to
+// filter or explain a Diagnostic without parsing its Message.
+const (
+	CodeUnexpectedNode = "unexpected-node"
+	CodeMissingNode    = "missing-node"
+	CodeUnmatchedDelim = "unmatched-delimiter"
+)
+
+// Range identifies the span of source a Diagnostic refers to, both as
+// byte offsets and as zero-based (row, column) points.
+type Range struct {
+	StartByte, EndByte   uint
+	StartPoint, EndPoint tree_sitter.Point
+}
+
+// Diagnostic describes a single problem found while walking a parsed
+// tree.
+type Diagnostic struct {
+	Range    Range
+	Severity Severity
+	Message  string
+	Code     string
+}
+
+var closingDelimiter = map[string]string{
+	"(": ")",
+	"{": "}",
+	"[": "]",
+}
+
+// Walk traverses tree and reports one Diagnostic per ERROR or MISSING
+// node it finds. ERROR nodes additionally get checked for unmatched
+// opening delimiters among their children.
+func Walk(tree *tree_sitter_rlox.Tree) []Diagnostic {
+	var diags []Diagnostic
+	tree.WalkPreorder(func(n tree_sitter.Node) bool {
+		switch {
+		case n.IsMissing():
+			diags = append(diags, Diagnostic{
+				Range:    rangeOf(n),
+				Severity: SeverityError,
+				Message:  fmt.Sprintf("missing %s", n.Type()),
+				Code:     CodeMissingNode,
+			})
+		case n.IsError():
+			diags = append(diags, Diagnostic{
+				Range:    rangeOf(n),
+				Severity: SeverityError,
+				Message:  fmt.Sprintf("unexpected %s", n.Type()),
+				Code:     CodeUnexpectedNode,
+			})
+			diags = append(diags, unmatchedDelimiters(n)...)
+		}
+		return true
+	})
+	return diags
+}
+
+// LSPDiagnostics walks tree and returns its diagnostics, ready to feed
+// into a textDocument/publishDiagnostics notification: Range.StartPoint
+// and Range.EndPoint are already zero-based (row, column) pairs in the
+// LSP convention. Unlike Walk, each Message whose Range covers source
+// text is annotated with that text so it reads sensibly in an editor
+// without the caller having to re-slice src itself. Zero-width
+// diagnostics, such as those for MISSING nodes, keep Walk's Message.
+func LSPDiagnostics(tree *tree_sitter_rlox.Tree, src []byte) []Diagnostic {
+	diags := Walk(tree)
+	for i := range diags {
+		if text := sourceText(src, diags[i].Range); text != "" {
+			diags[i].Message = fmt.Sprintf("%s: %q", diags[i].Message, text)
+		}
+	}
+	return diags
+}
+
+func sourceText(src []byte, r Range) string {
+	if r.StartByte >= r.EndByte || int(r.EndByte) > len(src) {
+		return ""
+	}
+	return string(src[r.StartByte:r.EndByte])
+}
+
+// unmatchedDelimiters reports any opening "(", "{" or "[" among errNode's
+// direct children that has no matching closing delimiter among its
+// siblings, which is how a dangling bracket shows up inside an ERROR
+// node's parent context.
+func unmatchedDelimiters(errNode tree_sitter.Node) []Diagnostic {
+	depth := map[string]int{}
+	for i := 0; i < int(errNode.ChildCount()); i++ {
+		t := errNode.Child(i).Type()
+		if _, ok := closingDelimiter[t]; ok {
+			depth[t]++
+			continue
+		}
+		for open, close := range closingDelimiter {
+			if t == close {
+				depth[open]--
+			}
+		}
+	}
+
+	var diags []Diagnostic
+	for open, close := range closingDelimiter {
+		if depth[open] > 0 {
+			diags = append(diags, Diagnostic{
+				Range:    rangeOf(errNode),
+				Severity: SeverityError,
+				Message:  fmt.Sprintf("unmatched %q, expected a closing %q", open, close),
+				Code:     CodeUnmatchedDelim,
+			})
+		}
+	}
+	return diags
+}
+
+func rangeOf(n tree_sitter.Node) Range {
+	return Range{
+		StartByte:  n.StartByte(),
+		EndByte:    n.EndByte(),
+		StartPoint: n.StartPosition(),
+		EndPoint:   n.EndPosition(),
+	}
+}
@@ -0,0 +1,88 @@
+package diagnostic_test
+
+import (
+	"testing"
+
+	tree_sitter_rlox "github.com/abrishk26/rlox/bindings/go"
+	"github.com/abrishk26/rlox/bindings/go/diagnostic"
+)
+
+func TestWalk(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		wantCode string
+	}{
+		{
+			name:     "missing semicolon",
+			src:      "var x = 1\nprint x;",
+			wantCode: diagnostic.CodeMissingNode,
+		},
+		{
+			name:     "unmatched opening paren",
+			src:      "print (1 + 2;",
+			wantCode: diagnostic.CodeUnmatchedDelim,
+		},
+		{
+			name:     "stray token",
+			src:      "var = 1;",
+			wantCode: diagnostic.CodeUnexpectedNode,
+		},
+		{
+			name:     "valid program",
+			src:      "var x = 1;\nprint x;",
+			wantCode: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := tree_sitter_rlox.NewParser()
+			if err != nil {
+				t.Fatal(err)
+			}
+			tree := p.Parse([]byte(tt.src))
+
+			diags := diagnostic.Walk(tree)
+
+			if tt.wantCode == "" {
+				if len(diags) != 0 {
+					t.Fatalf("expected no diagnostics, got %+v", diags)
+				}
+				return
+			}
+
+			found := false
+			for _, d := range diags {
+				if d.Code == tt.wantCode {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Errorf("expected a diagnostic with code %q, got %+v", tt.wantCode, diags)
+			}
+		})
+	}
+}
+
+func TestLSPDiagnosticsAnnotatesWithSourceText(t *testing.T) {
+	src := "var = 1;"
+	p, err := tree_sitter_rlox.NewParser()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree := p.Parse([]byte(src))
+
+	walked := diagnostic.Walk(tree)
+	lsp := diagnostic.LSPDiagnostics(tree, []byte(src))
+
+	if len(lsp) != len(walked) {
+		t.Fatalf("LSPDiagnostics returned %d diagnostics, Walk returned %d", len(lsp), len(walked))
+	}
+	for i, d := range lsp {
+		if d.Message == walked[i].Message {
+			t.Errorf("expected LSPDiagnostics to annotate message %q with source text, got it unchanged", walked[i].Message)
+		}
+	}
+}
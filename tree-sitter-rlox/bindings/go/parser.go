@@ -0,0 +1,97 @@
+package tree_sitter_rlox
+
+import (
+	"context"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// Parser wraps a tree_sitter.Parser pre-configured for the Rlox grammar.
+type Parser struct {
+	inner *tree_sitter.Parser
+}
+
+// NewParser returns a Parser ready to parse Rlox source.
+func NewParser() (*Parser, error) {
+	p := tree_sitter.NewParser()
+	if err := p.SetLanguage(tree_sitter.NewLanguage(Language())); err != nil {
+		return nil, err
+	}
+	return &Parser{inner: p}, nil
+}
+
+// Tree wraps a tree_sitter.Tree produced from Rlox source, keeping the
+// source bytes it was parsed from alongside it.
+type Tree struct {
+	inner *tree_sitter.Tree
+	src   []byte
+}
+
+// Parse parses src from scratch. It is equivalent to calling ParseCtx
+// with a background context and a nil old tree.
+func (p *Parser) Parse(src []byte) *Tree {
+	return p.ParseCtx(context.Background(), nil, src)
+}
+
+// ParseCtx parses src, reusing old as a starting point when it is
+// non-nil so that unaffected subtrees are not re-parsed. Callers doing
+// incremental edits should call old.Edit beforehand so the parser knows
+// which ranges changed.
+func (p *Parser) ParseCtx(ctx context.Context, old *Tree, src []byte) *Tree {
+	var oldInner *tree_sitter.Tree
+	if old != nil {
+		oldInner = old.inner
+	}
+	return &Tree{inner: p.inner.ParseCtx(ctx, src, oldInner), src: src}
+}
+
+// RootNode returns the root node of the tree.
+func (t *Tree) RootNode() tree_sitter.Node {
+	return t.inner.RootNode()
+}
+
+// NamedDescendantForRange returns the smallest named node spanning
+// [start, end), which is the usual unit editors want when mapping a
+// cursor position or selection back onto the syntax tree.
+func (t *Tree) NamedDescendantForRange(start, end tree_sitter.Point) tree_sitter.Node {
+	root := t.RootNode()
+	return root.NamedDescendantForPointRange(start, end)
+}
+
+// WalkPreorder visits every node in the tree in preorder (a node before
+// its children, children left-to-right). Walking stops as soon as fn
+// returns false.
+func (t *Tree) WalkPreorder(fn func(n tree_sitter.Node) bool) {
+	walkPreorder(t.RootNode(), fn)
+}
+
+func walkPreorder(n tree_sitter.Node, fn func(tree_sitter.Node) bool) bool {
+	if !fn(n) {
+		return false
+	}
+	for i := 0; i < int(n.ChildCount()); i++ {
+		if !walkPreorder(n.Child(i), fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// Edit records that the bytes [startByte, oldEndByte) were replaced by
+// new content ending at byte newEndByte, at the given source
+// positions, and marks the affected ranges of the tree as changed.
+// Call this before ParseCtx so the parser can skip re-parsing subtrees
+// outside the edited range. Both the byte offsets and the points are
+// required: ts_tree_edit uses the byte offsets to find which ranges
+// changed and the points to keep line/column bookkeeping on nodes
+// outside the edit correct.
+func (t *Tree) Edit(startByte, oldEndByte, newEndByte uint, start, oldEnd, newEnd tree_sitter.Point) {
+	t.inner.Edit(&tree_sitter.InputEdit{
+		StartByte:   startByte,
+		OldEndByte:  oldEndByte,
+		NewEndByte:  newEndByte,
+		StartPoint:  start,
+		OldEndPoint: oldEnd,
+		NewEndPoint: newEnd,
+	})
+}
@@ -0,0 +1,84 @@
+package tree_sitter_rlox_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	tree_sitter_rlox "github.com/abrishk26/rlox/bindings/go"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+const injected = "var injected = 1;\n"
+
+// BenchmarkParseFull reparses the whole fixture from scratch every
+// iteration, the baseline every edit pays without incremental parsing.
+func BenchmarkParseFull(b *testing.B) {
+	src := readLargeSource(b)
+	p, err := tree_sitter_rlox.NewParser()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.Parse(src)
+	}
+}
+
+// BenchmarkParseIncremental applies a single small insertion to a
+// previously parsed tree and reparses with ParseCtx, which should only
+// redo work for the edited region and its ancestors.
+func BenchmarkParseIncremental(b *testing.B) {
+	src := readLargeSource(b)
+	p, err := tree_sitter_rlox.NewParser()
+	if err != nil {
+		b.Fatal(err)
+	}
+	tree := p.Parse(src)
+
+	insertAt := len(src) / 2
+	edited := make([]byte, 0, len(src)+len(injected))
+	edited = append(edited, src[:insertAt]...)
+	edited = append(edited, injected...)
+	edited = append(edited, src[insertAt:]...)
+
+	start := pointAt(src, insertAt)
+	oldEnd := start
+	newEnd := pointAt(edited, insertAt+len(injected))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// Each iteration edits a fresh tree of the original source, so
+		// the recorded insertion always matches the text being reparsed.
+		b.StopTimer()
+		tree = p.Parse(src)
+		b.StartTimer()
+		tree.Edit(uint(insertAt), uint(insertAt), uint(insertAt+len(injected)), start, oldEnd, newEnd)
+		p.ParseCtx(context.Background(), tree, edited)
+	}
+}
+
+// pointAt returns the (row, column) of byte offset b within src, both
+// zero-based.
+func pointAt(src []byte, b int) tree_sitter.Point {
+	row, col := uint(0), uint(0)
+	for _, c := range src[:b] {
+		if c == '\n' {
+			row++
+			col = 0
+		} else {
+			col++
+		}
+	}
+	return tree_sitter.Point{Row: row, Column: col}
+}
+
+func readLargeSource(b *testing.B) []byte {
+	b.Helper()
+	src, err := os.ReadFile("../../testdata/large.lox")
+	if err != nil {
+		b.Fatal(err)
+	}
+	return src
+}
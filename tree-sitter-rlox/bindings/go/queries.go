@@ -0,0 +1,68 @@
+package tree_sitter_rlox
+
+import (
+	_ "embed"
+	"fmt"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+//go:embed ../../queries/highlights.scm
+var highlightsQuery string
+
+//go:embed ../../queries/locals.scm
+var localsQuery string
+
+//go:embed ../../queries/tags.scm
+var tagsQuery string
+
+// HighlightsQuery returns the query source used to drive syntax
+// highlighting: keywords, literals, calls, and operators.
+func HighlightsQuery() string { return highlightsQuery }
+
+// LocalsQuery returns the query source used to resolve block and
+// function scopes along with the variable definitions and references
+// within them, for building a symbol index.
+func LocalsQuery() string { return localsQuery }
+
+// TagsQuery returns the query source that locates class and function
+// definitions, for go-to-definition and outline views.
+func TagsQuery() string { return tagsQuery }
+
+// NewQuery compiles src for lang and, on failure, rewrites the error to
+// point at the offending line and column within src rather than a raw
+// byte offset.
+func NewQuery(lang *tree_sitter.Language, src string) (*tree_sitter.Query, error) {
+	q, err := tree_sitter.NewQuery(lang, src)
+	if err != nil {
+		line, col := lineAndColumn(src, queryErrorOffset(err))
+		return nil, fmt.Errorf("rlox: invalid query at %d:%d: %w", line, col, err)
+	}
+	return q, nil
+}
+
+// queryErrorOffset extracts the byte offset tree_sitter.NewQuery
+// reports a parse failure at, defaulting to 0 for error types that
+// don't carry one.
+func queryErrorOffset(err error) uint {
+	if qerr, ok := err.(*tree_sitter.QueryError); ok {
+		return qerr.Offset
+	}
+	return 0
+}
+
+func lineAndColumn(src string, offset uint) (line, col int) {
+	line, col = 1, 1
+	for i, r := range src {
+		if uint(i) >= offset {
+			break
+		}
+		if r == '\n' {
+			line++
+			col = 1
+		} else {
+			col++
+		}
+	}
+	return line, col
+}
@@ -0,0 +1,45 @@
+package tree_sitter_rlox_test
+
+import (
+	"strings"
+	"testing"
+
+	tree_sitter_rlox "github.com/abrishk26/rlox/bindings/go"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+func TestQueriesCompile(t *testing.T) {
+	lang := tree_sitter.NewLanguage(tree_sitter_rlox.Language())
+
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{"highlights", tree_sitter_rlox.HighlightsQuery()},
+		{"locals", tree_sitter_rlox.LocalsQuery()},
+		{"tags", tree_sitter_rlox.TagsQuery()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if strings.TrimSpace(tt.src) == "" {
+				t.Fatalf("%s query is empty", tt.name)
+			}
+			if _, err := tree_sitter_rlox.NewQuery(lang, tt.src); err != nil {
+				t.Errorf("%s query failed to compile: %v", tt.name, err)
+			}
+		})
+	}
+}
+
+func TestNewQueryReportsPosition(t *testing.T) {
+	lang := tree_sitter.NewLanguage(tree_sitter_rlox.Language())
+
+	_, err := tree_sitter_rlox.NewQuery(lang, "(\nbogus_node)")
+	if err == nil {
+		t.Fatal("expected an error for an invalid query")
+	}
+	if !strings.Contains(err.Error(), "2:") {
+		t.Errorf("expected error to reference line 2, got: %v", err)
+	}
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	tree_sitter_rlox "github.com/abrishk26/rlox/bindings/go"
+	"github.com/abrishk26/rlox/bindings/go/ast"
+	"github.com/abrishk26/rlox/bindings/go/diagnostic"
+)
+
+// Format parses src and returns its canonical formatting. It refuses to
+// format source that doesn't parse cleanly, returning a *formatError
+// describing every diagnostic instead of guessing at the writer's
+// intent.
+func Format(src []byte) ([]byte, error) {
+	p, err := tree_sitter_rlox.NewParser()
+	if err != nil {
+		return nil, err
+	}
+	tree := p.Parse(src)
+
+	if diags := diagnostic.Walk(tree); len(diags) > 0 {
+		return nil, &formatError{diags: diags}
+	}
+
+	program, err := ast.FromTree(tree, src)
+	if err != nil {
+		return nil, err
+	}
+
+	var pr printer
+	pr.program(program)
+	return []byte(pr.buf.String()), nil
+}
+
+type formatError struct {
+	diags []diagnostic.Diagnostic
+}
+
+func (e *formatError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "refusing to format: %d syntax error(s) found", len(e.diags))
+	for _, d := range e.diags {
+		fmt.Fprintf(&b, "\n  %d:%d: %s", d.Range.StartPoint.Row+1, d.Range.StartPoint.Column+1, d.Message)
+	}
+	return b.String()
+}
+
+const indentUnit = "  "
+
+type printer struct {
+	buf    bytes.Buffer
+	indent int
+}
+
+func (p *printer) writeIndent() {
+	p.buf.WriteString(strings.Repeat(indentUnit, p.indent))
+}
+
+func (p *printer) program(prog *ast.Program) {
+	for i, d := range prog.Decls {
+		if i > 0 {
+			p.buf.WriteString("\n")
+		}
+		p.stmt(d)
+	}
+}
+
+func (p *printer) stmt(s ast.Stmt) {
+	p.writeIndent()
+
+	switch s := s.(type) {
+	case *ast.VarDecl:
+		if s.Init != nil {
+			fmt.Fprintf(&p.buf, "var %s = %s;\n", s.Name, p.expr(s.Init))
+		} else {
+			fmt.Fprintf(&p.buf, "var %s;\n", s.Name)
+		}
+
+	case *ast.FunDecl:
+		p.funDecl(s, "fun ")
+
+	case *ast.ClassDecl:
+		if s.Superclass != nil {
+			fmt.Fprintf(&p.buf, "class %s < %s {\n", s.Name, s.Superclass.Name)
+		} else {
+			fmt.Fprintf(&p.buf, "class %s {\n", s.Name)
+		}
+		p.indent++
+		for i, m := range s.Methods {
+			if i > 0 {
+				p.buf.WriteString("\n")
+			}
+			p.writeIndent()
+			p.funDecl(m, "")
+		}
+		p.indent--
+		p.writeIndent()
+		p.buf.WriteString("}\n")
+
+	case *ast.Block:
+		p.block(s)
+		p.buf.WriteString("\n")
+
+	case *ast.IfStmt:
+		fmt.Fprintf(&p.buf, "if (%s) ", p.expr(s.Cond))
+		p.inlineStmt(s.Then)
+		if s.Else != nil {
+			p.buf.WriteString(" else ")
+			p.inlineStmt(s.Else)
+		}
+		p.buf.WriteString("\n")
+
+	case *ast.WhileStmt:
+		fmt.Fprintf(&p.buf, "while (%s) ", p.expr(s.Cond))
+		p.inlineStmt(s.Body)
+		p.buf.WriteString("\n")
+
+	case *ast.ForStmt:
+		fmt.Fprintf(&p.buf, "for (%s %s; %s) ", p.forInit(s.Init), p.exprOr(s.Cond, ""), p.exprOr(s.Post, ""))
+		p.inlineStmt(s.Body)
+		p.buf.WriteString("\n")
+
+	case *ast.ReturnStmt:
+		if s.Value != nil {
+			fmt.Fprintf(&p.buf, "return %s;\n", p.expr(s.Value))
+		} else {
+			p.buf.WriteString("return;\n")
+		}
+
+	case *ast.PrintStmt:
+		fmt.Fprintf(&p.buf, "print %s;\n", p.expr(s.Value))
+
+	case *ast.ExprStmt:
+		fmt.Fprintf(&p.buf, "%s;\n", p.expr(s.Expr))
+	}
+}
+
+// inlineStmt prints a statement that follows `if (...)`/`while (...)`
+// on the same line, e.g. a block opening brace, without a leading
+// indent of its own.
+// inlineStmt prints a statement that follows `if (...)`/`while (...)`
+// on the same line. p.stmt always terminates its output with "\n" so
+// that top-level declarations end up one per line; here that would
+// leave a blank line before the newline the caller adds after the
+// whole if/while/for, so it's trimmed back off for the non-block case.
+func (p *printer) inlineStmt(s ast.Stmt) {
+	if b, ok := s.(*ast.Block); ok {
+		p.block(b)
+		return
+	}
+	saved := p.indent
+	p.indent = 0
+	p.stmt(s)
+	p.indent = saved
+
+	if out := p.buf.Bytes(); len(out) > 0 && out[len(out)-1] == '\n' {
+		p.buf.Truncate(p.buf.Len() - 1)
+	}
+}
+
+func (p *printer) block(b *ast.Block) {
+	p.buf.WriteString("{\n")
+	p.indent++
+	for _, s := range b.Stmts {
+		p.stmt(s)
+	}
+	p.indent--
+	p.writeIndent()
+	p.buf.WriteString("}")
+}
+
+func (p *printer) funDecl(f *ast.FunDecl, prefix string) {
+	fmt.Fprintf(&p.buf, "%s%s(%s) ", prefix, f.Name, strings.Join(f.Params, ", "))
+	p.block(&ast.Block{Stmts: f.Body})
+	p.buf.WriteString("\n")
+}
+
+func (p *printer) forInit(s ast.Stmt) string {
+	switch s := s.(type) {
+	case nil:
+		return ";"
+	case *ast.VarDecl:
+		if s.Init != nil {
+			return fmt.Sprintf("var %s = %s;", s.Name, p.expr(s.Init))
+		}
+		return fmt.Sprintf("var %s;", s.Name)
+	case *ast.ExprStmt:
+		return p.expr(s.Expr) + ";"
+	default:
+		return ";"
+	}
+}
+
+func (p *printer) exprOr(e ast.Expr, fallback string) string {
+	if e == nil {
+		return fallback
+	}
+	return p.expr(e)
+}
+
+func (p *printer) expr(e ast.Expr) string {
+	switch e := e.(type) {
+	case *ast.Binary:
+		return fmt.Sprintf("%s %s %s", p.expr(e.Left), e.Op, p.expr(e.Right))
+	case *ast.Logical:
+		return fmt.Sprintf("%s %s %s", p.expr(e.Left), e.Op, p.expr(e.Right))
+	case *ast.Unary:
+		return fmt.Sprintf("%s%s", e.Op, p.expr(e.Right))
+	case *ast.Grouping:
+		return fmt.Sprintf("(%s)", p.expr(e.Expr))
+	case *ast.Call:
+		args := make([]string, len(e.Args))
+		for i, a := range e.Args {
+			args[i] = p.expr(a)
+		}
+		return fmt.Sprintf("%s(%s)", p.expr(e.Callee), strings.Join(args, ", "))
+	case *ast.Get:
+		return fmt.Sprintf("%s.%s", p.expr(e.Object), e.Name)
+	case *ast.Set:
+		return fmt.Sprintf("%s.%s = %s", p.expr(e.Object), e.Name, p.expr(e.Value))
+	case *ast.Super:
+		return fmt.Sprintf("super.%s", e.Method)
+	case *ast.This:
+		return "this"
+	case *ast.Literal:
+		return fmt.Sprintf("%v", e.Value)
+	case *ast.Variable:
+		return e.Name
+	case *ast.Assign:
+		return fmt.Sprintf("%s = %s", e.Name, p.expr(e.Value))
+	default:
+		return ""
+	}
+}
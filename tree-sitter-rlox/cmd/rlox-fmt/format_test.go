@@ -0,0 +1,61 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFormatGolden(t *testing.T) {
+	inputs, err := filepath.Glob("../../testdata/fmt/*.input.lox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inputs) == 0 {
+		t.Fatal("no fixtures found under testdata/fmt")
+	}
+
+	for _, inputPath := range inputs {
+		name := filepath.Base(inputPath)
+		t.Run(name, func(t *testing.T) {
+			goldenPath := filepath.Join(filepath.Dir(inputPath), trimSuffix(name, ".input.lox")+".golden.lox")
+
+			src, err := os.ReadFile(inputPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := Format(src)
+			if err != nil {
+				t.Fatalf("Format(%s): %v", name, err)
+			}
+			if string(got) != string(want) {
+				t.Errorf("Format(%s) = %q, want %q", name, got, want)
+			}
+
+			// Formatting already-formatted output must be a no-op.
+			again, err := Format(got)
+			if err != nil {
+				t.Fatalf("Format(golden output for %s): %v", name, err)
+			}
+			if string(again) != string(got) {
+				t.Errorf("Format is not idempotent for %s: got %q, then %q", name, got, again)
+			}
+		})
+	}
+}
+
+func TestFormatRefusesSyntaxErrors(t *testing.T) {
+	_, err := Format([]byte("var x = ;"))
+	if err == nil {
+		t.Fatal("expected Format to refuse source with a syntax error")
+	}
+}
+
+func trimSuffix(name, suffix string) string {
+	return name[:len(name)-len(suffix)]
+}